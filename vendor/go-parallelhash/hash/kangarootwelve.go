@@ -0,0 +1,289 @@
+package hash
+
+import (
+	"hash"
+	"io"
+	"sync"
+)
+
+// --- KangarooTwelve / MarsupilamiFourteen ---
+//
+// Both are tree hashes built on a reduced-round Keccak-p permutation
+// (see keccakp.go) instead of the full 24-round Keccak-f used by
+// ParallelHash. The message is split into 8192-byte chunks; the first chunk
+// is combined directly with a chaining node made of the 12/14-round hashes
+// of the remaining chunks (the "CV"s). Every node, the final one included,
+// uses the same reduced-round permutation (12 rounds for K12, 14 for M14).
+
+const (
+	k12ChunkSize = 8192
+
+	// Domain-separation suffixes. Leaves use 0x0B; the final node of a
+	// multi-chunk tree uses 0x06; a message that fits in a single chunk
+	// (no tree at all) is hashed directly with 0x07, distinct from the
+	// tree-final node.
+	k12LeafDomain   = 0x0B
+	k12FinalDomain  = 0x06
+	k12SingleDomain = 0x07
+)
+
+// k12Rate/k12CVSize and m14Rate/m14CVSize fix the sponge rate (bytes) and
+// chaining-value size (bytes) for each construction: K12 targets 128-bit
+// security with a 256-bit CV (same rate as cSHAKE128), M14 targets 256-bit
+// security with a 512-bit CV (same rate as cSHAKE256).
+const (
+	k12Rate  = 168
+	k12CV    = 32
+	k12Round = 12
+
+	m14Rate  = 136
+	m14CV    = 64
+	m14Round = 14
+)
+
+// treeHash implements the shared K12/M14 tree structure over X with
+// customization string C, producing L bits of output.
+func treeHash(X, C []byte, L int, rate, cvSize, rounds int) []byte {
+	msg := append(append([]byte(nil), X...), C...)
+	msg = append(msg, lengthEncode(len(C))...)
+
+	if len(msg) <= k12ChunkSize {
+		s := newKeccakSponge(rate, rounds)
+		s.Write(msg)
+		s.pad(k12SingleDomain)
+		out := make([]byte, L/8)
+		s.Read(out)
+		return out
+	}
+
+	first := msg[:k12ChunkSize]
+	chunks := splitChunks(msg[k12ChunkSize:])
+
+	// Hash each chunk's CV in its own goroutine, mirroring the worker
+	// pattern used by parallelHashGoroutines.
+	cvs := make([][]byte, len(chunks))
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s := newKeccakSponge(rate, rounds)
+			s.Write(chunks[i])
+			s.pad(k12LeafDomain)
+			cv := make([]byte, cvSize)
+			s.Read(cv)
+			cvs[i] = cv
+		}(i)
+	}
+	wg.Wait()
+
+	nodeStar := append([]byte(nil), first...)
+	nodeStar = append(nodeStar, 0x03)
+	nodeStar = append(nodeStar, 0, 0, 0, 0, 0, 0, 0)
+	for _, cv := range cvs {
+		nodeStar = append(nodeStar, cv...)
+	}
+	nodeStar = append(nodeStar, lengthEncode(len(chunks))...)
+	nodeStar = append(nodeStar, 0xFF, 0xFF)
+
+	s := newKeccakSponge(rate, rounds)
+	s.Write(nodeStar)
+	s.pad(k12FinalDomain)
+	out := make([]byte, L/8)
+	s.Read(out)
+	return out
+}
+
+// splitChunks divides b into consecutive pieces of at most k12ChunkSize
+// bytes, the last possibly shorter. treeHash and k12XOF.finalize both use
+// it so their chunk boundaries -- and therefore their output -- agree.
+func splitChunks(b []byte) [][]byte {
+	n := (len(b) + k12ChunkSize - 1) / k12ChunkSize
+	chunks := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		start := i * k12ChunkSize
+		end := start + k12ChunkSize
+		if end > len(b) {
+			end = len(b)
+		}
+		chunks[i] = b[start:end]
+	}
+	return chunks
+}
+
+// lengthEncode encodes x as a little-endian byte string followed by its own
+// length, matching the length_encode() helper used throughout the K12/M14
+// specification (distinct from the big-endian left_encode/right_encode used
+// by the SP 800-185 constructions elsewhere in this package).
+func lengthEncode(x int) []byte {
+	if x == 0 {
+		return []byte{0}
+	}
+	var b []byte
+	for v := x; v > 0; v >>= 8 {
+		b = append(b, byte(v))
+	}
+	return append(b, byte(len(b)))
+}
+
+// KangarooTwelve computes the KangarooTwelve hash of X with customization
+// string C, as described by Bertoni et al., using 12-round Keccak-p as the
+// underlying permutation. L is the desired output length in bits.
+func KangarooTwelve(X []byte, C []byte, L int) []byte {
+	return treeHash(X, C, L, k12Rate, k12CV, k12Round)
+}
+
+// MarsupilamiFourteen computes the MarsupilamiFourteen hash of X with
+// customization string C. It is the 256-bit-security counterpart to
+// KangarooTwelve: the same tree structure with a 512-bit chaining value and
+// 14-round Keccak-p.
+func MarsupilamiFourteen(X []byte, C []byte, L int) []byte {
+	return treeHash(X, C, L, m14Rate, m14CV, m14Round)
+}
+
+// k12XOF is the streaming, arbitrary-output-length counterpart of
+// KangarooTwelve/MarsupilamiFourteen returned by NewK12/NewM14. It buffers
+// at most one chunk ahead of the data it has committed to the tree, so
+// gigabyte-scale input never needs to be held in memory: completed chunks
+// are hashed into chaining values (one at a time, on the caller's
+// goroutine) as soon as a later chunk proves they were not the last one.
+type k12XOF struct {
+	C      []byte
+	rate   int
+	cvSize int
+	rounds int
+
+	first   []byte // the captured first chunk, once more than one chunk exists
+	pending []byte // the latest not-yet-committed chunk (may still grow)
+	cvs     [][]byte
+
+	final *keccakSponge // set by finalize; nil until then
+}
+
+func newK12XOF(C []byte, rate, cvSize, rounds int) *k12XOF {
+	return &k12XOF{C: append([]byte(nil), C...), rate: rate, cvSize: cvSize, rounds: rounds}
+}
+
+// commitPending hashes k.pending into either k.first (if it is the very
+// first chunk) or a new chaining value, because a later Write proved it was
+// not the final chunk of the message.
+func (k *k12XOF) commitPending() {
+	if k.first == nil {
+		k.first = k.pending
+	} else {
+		s := newKeccakSponge(k.rate, k.rounds)
+		s.Write(k.pending)
+		s.pad(k12LeafDomain)
+		cv := make([]byte, k.cvSize)
+		s.Read(cv)
+		k.cvs = append(k.cvs, cv)
+	}
+	k.pending = nil
+}
+
+func (k *k12XOF) Write(p []byte) (int, error) {
+	n := len(p)
+	k.pending = append(k.pending, p...)
+	for len(k.pending) > k12ChunkSize {
+		chunk := k.pending[:k12ChunkSize:k12ChunkSize]
+		rest := append([]byte(nil), k.pending[k12ChunkSize:]...)
+		k.pending = chunk
+		k.commitPending()
+		k.pending = rest
+	}
+	return n, nil
+}
+
+// finalize appends C||lengthEncode(len(C)) to whatever of the message is
+// still buffered in k.pending and chunks that tail exactly as treeHash
+// chunks the corresponding tail of a one-shot message -- including the
+// case where the tail alone spills over an 8192-byte boundary, which is
+// why it cannot simply hash k.pending||C||lengthEncode(len(C)) as one
+// chunk the way an earlier version of this method did.
+func (k *k12XOF) finalize() *keccakSponge {
+	tail := append(append([]byte(nil), k.pending...), k.C...)
+	tail = append(tail, lengthEncode(len(k.C))...)
+
+	if k.first == nil && len(tail) <= k12ChunkSize {
+		// Single-chunk message: hash directly, no tree.
+		s := newKeccakSponge(k.rate, k.rounds)
+		s.Write(tail)
+		s.pad(k12SingleDomain)
+		return s
+	}
+
+	first := k.first
+	tailChunks := splitChunks(tail)
+	if first == nil {
+		first = tailChunks[0]
+		tailChunks = tailChunks[1:]
+	}
+
+	cvs := append([][]byte(nil), k.cvs...)
+	for _, chunk := range tailChunks {
+		s := newKeccakSponge(k.rate, k.rounds)
+		s.Write(chunk)
+		s.pad(k12LeafDomain)
+		cv := make([]byte, k.cvSize)
+		s.Read(cv)
+		cvs = append(cvs, cv)
+	}
+
+	nodeStar := append([]byte(nil), first...)
+	nodeStar = append(nodeStar, 0x03)
+	nodeStar = append(nodeStar, 0, 0, 0, 0, 0, 0, 0)
+	for _, cv := range cvs {
+		nodeStar = append(nodeStar, cv...)
+	}
+	nodeStar = append(nodeStar, lengthEncode(len(cvs))...)
+	nodeStar = append(nodeStar, 0xFF, 0xFF)
+
+	s := newKeccakSponge(k.rate, k.rounds)
+	s.Write(nodeStar)
+	s.pad(k12FinalDomain)
+	return s
+}
+
+func (k *k12XOF) Read(out []byte) (int, error) {
+	if k.final == nil {
+		k.final = k.finalize()
+	}
+	k.final.Read(out)
+	return len(out), nil
+}
+
+func (k *k12XOF) Sum(b []byte) []byte {
+	out := make([]byte, k.cvSize)
+	k.finalize().Read(out)
+	return append(b, out...)
+}
+
+func (k *k12XOF) Reset() {
+	k.first = nil
+	k.pending = nil
+	k.cvs = nil
+	k.final = nil
+}
+
+func (k *k12XOF) Size() int      { return k.cvSize }
+func (k *k12XOF) BlockSize() int { return k12ChunkSize }
+
+// k12ShakeHash is the interface satisfied by NewK12/NewM14: a fixed-length
+// hash.Hash plus an io.Reader for arbitrary XOF output, mirroring
+// ParallelShakeHash and sha3.ShakeHash.
+type k12ShakeHash interface {
+	hash.Hash
+	io.Reader
+}
+
+// NewK12 returns a streaming KangarooTwelve state. C is the customization
+// string.
+func NewK12(C []byte) k12ShakeHash {
+	return newK12XOF(C, k12Rate, k12CV, k12Round)
+}
+
+// NewM14 returns a streaming MarsupilamiFourteen state. C is the
+// customization string.
+func NewM14(C []byte) k12ShakeHash {
+	return newK12XOF(C, m14Rate, m14CV, m14Round)
+}
@@ -0,0 +1,67 @@
+package hash
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// streamAndCompare checks that writing X (split into two unevenly-sized
+// pieces, to exercise Write's internal buffering) through a streaming XOF
+// matches the one-shot function, for both an empty and a non-empty C. It is
+// used to check exact-chunk-boundary sizes, where the streaming and
+// one-shot chunkings have historically diverged.
+func streamAndCompare(t *testing.T, size int, newXOF func([]byte) k12ShakeHash, oneShot func(X, C []byte, L int) []byte) {
+	t.Helper()
+	X := make([]byte, size)
+	rand.Read(X)
+
+	for _, C := range [][]byte{nil, []byte("customization")} {
+		want := oneShot(X, C, 512)
+
+		xof := newXOF(C)
+		if size > 1 {
+			xof.Write(X[:size/2])
+			xof.Write(X[size/2:])
+		} else {
+			xof.Write(X)
+		}
+		got := make([]byte, 64)
+		if _, err := xof.Read(got); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Fatalf("size %d, C=%q: streaming XOF diverges from one-shot:\n got=%x\nwant=%x", size, C, got, want)
+		}
+	}
+}
+
+func TestK12StreamingMatchesOneShot(t *testing.T) {
+	for _, size := range []int{0, 1, 100, 8191, 8192, 8193, 16384, 16385, 24576, 24577} {
+		streamAndCompare(t, size, NewK12, KangarooTwelve)
+	}
+}
+
+func TestM14StreamingMatchesOneShot(t *testing.T) {
+	for _, size := range []int{0, 1, 100, 8191, 8192, 8193, 16384, 16385, 24576, 24577} {
+		streamAndCompare(t, size, NewM14, MarsupilamiFourteen)
+	}
+}
+
+// TestK12SumMatchesKangarooTwelve checks the fixed-length hash.Hash view
+// (Sum) agrees with KangarooTwelve's natural 256-bit output.
+func TestK12SumMatchesKangarooTwelve(t *testing.T) {
+	data := make([]byte, 20000)
+	rand.Read(data)
+
+	want := KangarooTwelve(data, []byte("C"), 256)
+
+	h := NewK12([]byte("C"))
+	h.Write(data)
+	got := h.Sum(nil)
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("Sum diverges from KangarooTwelve:\n got=%x\nwant=%x", got, want)
+	}
+}
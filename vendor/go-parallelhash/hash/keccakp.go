@@ -0,0 +1,156 @@
+package hash
+
+// keccakp.go implements the Keccak-p[1600, n_r] permutation with a
+// configurable round count. golang.org/x/crypto/sha3 only exposes the full
+// 24-round Keccak-f[1600] (via the SHAKE/cSHAKE constructions used
+// elsewhere in this package), so the reduced-round permutation needed by
+// KangarooTwelve and MarsupilamiFourteen is implemented here directly.
+
+// keccakRC holds the 24 round constants for the full Keccak-f[1600]
+// permutation. Keccak-p[1600, n_r] uses the last n_r of them, per the
+// Keccak-p definition (KangarooTwelve uses n_r=12, MarsupilamiFourteen
+// n_r=14).
+var keccakRC = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotation holds the rho-step rotation offsets, indexed [x][y].
+var keccakRotation = [5][5]uint{
+	{0, 36, 3, 41, 18},
+	{1, 44, 10, 45, 2},
+	{62, 6, 43, 15, 61},
+	{28, 55, 25, 21, 56},
+	{27, 20, 39, 8, 14},
+}
+
+func rotl64(x uint64, n uint) uint64 {
+	return x<<(n%64) | x>>(64-n%64)
+}
+
+// keccakPermute applies Keccak-p[1600, rounds] in place to a 25-lane state.
+func keccakPermute(a *[25]uint64, rounds int) {
+	var c [5]uint64
+	var d [5]uint64
+	var b [5][5]uint64
+
+	first := 24 - rounds
+	for round := first; round < 24; round++ {
+		// theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d[x] = c[(x+4)%5] ^ rotl64(c[(x+1)%5], 1)
+		}
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				a[x+5*y] ^= d[x]
+			}
+		}
+
+		// rho + pi
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				nx := y
+				ny := (2*x + 3*y) % 5
+				b[nx][ny] = rotl64(a[x+5*y], keccakRotation[x][y])
+			}
+		}
+
+		// chi
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				a[x+5*y] = b[x][y] ^ (^b[(x+1)%5][y] & b[(x+2)%5][y])
+			}
+		}
+
+		// iota
+		a[0] ^= keccakRC[round]
+	}
+}
+
+// keccakSponge is a minimal Keccak-p[1600, rounds] sponge used to build the
+// KangarooTwelve / MarsupilamiFourteen tree hashes. Unlike cshakeSponge it
+// does not go through golang.org/x/crypto/sha3, since that package only
+// exposes the full 24-round permutation.
+type keccakSponge struct {
+	state     [25]uint64
+	rate      int // rate, in bytes
+	rounds    int
+	absorbed  int // bytes absorbed into the current block
+	squeezed  int // bytes squeezed out of the current block
+	squeezing bool
+}
+
+func newKeccakSponge(rate, rounds int) *keccakSponge {
+	return &keccakSponge{rate: rate, rounds: rounds}
+}
+
+func (s *keccakSponge) xorByte(i int, v byte) {
+	shift := uint(8 * (i % 8))
+	s.state[i/8] ^= uint64(v) << shift
+}
+
+func (s *keccakSponge) byteAt(i int) byte {
+	shift := uint(8 * (i % 8))
+	return byte(s.state[i/8] >> shift)
+}
+
+// Write absorbs message bytes into the sponge.
+func (s *keccakSponge) Write(p []byte) {
+	for len(p) > 0 {
+		n := s.rate - s.absorbed
+		if n > len(p) {
+			n = len(p)
+		}
+		for k := 0; k < n; k++ {
+			s.xorByte(s.absorbed+k, p[k])
+		}
+		s.absorbed += n
+		p = p[n:]
+		if s.absorbed == s.rate {
+			keccakPermute(&s.state, s.rounds)
+			s.absorbed = 0
+		}
+	}
+}
+
+// pad finalizes absorption with the multi-rate padding 0||...||1 and the
+// given domain-separation suffix bits folded into the first padding byte,
+// as used throughout the SP 800-185 / K12 family (e.g. 0x0B, 0x06).
+func (s *keccakSponge) pad(domain byte) {
+	s.xorByte(s.absorbed, domain)
+	s.xorByte(s.rate-1, 0x80)
+	keccakPermute(&s.state, s.rounds)
+	s.absorbed = 0
+	s.squeezing = true
+}
+
+// Read squeezes output bytes from the sponge. pad must be called first.
+func (s *keccakSponge) Read(out []byte) {
+	for len(out) > 0 {
+		if s.squeezed == s.rate {
+			keccakPermute(&s.state, s.rounds)
+			s.squeezed = 0
+		}
+		n := s.rate - s.squeezed
+		if n > len(out) {
+			n = len(out)
+		}
+		for k := 0; k < n; k++ {
+			out[k] = s.byteAt(s.squeezed + k)
+		}
+		s.squeezed += n
+		out = out[n:]
+	}
+}
+
+func (s *keccakSponge) clone() *keccakSponge {
+	clone := *s
+	return &clone
+}
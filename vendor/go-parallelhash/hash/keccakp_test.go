@@ -0,0 +1,37 @@
+package hash
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// TestKeccakPermuteMatchesReferenceSHAKE128 runs keccakSponge with the full,
+// unreduced 24-round Keccak-p[1600,24] (i.e. Keccak-f[1600]) at SHAKE128's
+// rate and domain-separation byte (0x1f) and checks it against
+// golang.org/x/crypto/sha3's own Keccak-f[1600]-based SHAKE128. Since SHAKE
+// and Keccak-p[1600,24] share the same permutation, rate, and padding rule,
+// any mismatch here means keccakPermute -- most likely keccakRotation, whose
+// rows/columns are easy to transpose by mistake -- disagrees with the
+// reference implementation.
+func TestKeccakPermuteMatchesReferenceSHAKE128(t *testing.T) {
+	for _, size := range []int{0, 1, 167, 168, 169, 1000} {
+		data := make([]byte, size)
+		rand.Read(data)
+
+		want := make([]byte, 64)
+		sha3.ShakeSum128(want, data)
+
+		s := newKeccakSponge(168, 24)
+		s.Write(data)
+		s.pad(0x1f)
+		got := make([]byte, 64)
+		s.Read(got)
+
+		if !bytes.Equal(want, got) {
+			t.Fatalf("size %d: keccakPermute output diverges from reference SHAKE128:\n got=%x\nwant=%x", size, got, want)
+		}
+	}
+}
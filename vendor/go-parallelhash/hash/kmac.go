@@ -0,0 +1,42 @@
+package hash
+
+// --- KMAC Implementations (NIST SP 800-185) ---
+
+// kmacNewX builds newX = bytepad(encode_string(K), rate) || X || suffix for
+// the given key K, message X, and trailing length-encoding suffix.
+func kmacNewX(K, X []byte, rate int, suffix []byte) []byte {
+	newX := bytepad(encodeString(K), rate)
+	newX = append(newX, X...)
+	newX = append(newX, suffix...)
+	return newX
+}
+
+// KMAC128 computes the KMAC128 keyed hash of X under key K, as defined in
+// NIST SP 800-185. L is the desired output length in bits and S is an
+// optional customization string.
+func KMAC128(K, X []byte, L int, S string) []byte {
+	newX := kmacNewX(K, X, 168, rightEncode(L))
+	return cSHAKE128(newX, L, "KMAC", S)
+}
+
+// KMAC256 computes the KMAC256 keyed hash of X under key K, as defined in
+// NIST SP 800-185.
+func KMAC256(K, X []byte, L int, S string) []byte {
+	newX := kmacNewX(K, X, 136, rightEncode(L))
+	return cSHAKE256(newX, L, "KMAC", S)
+}
+
+// KMACXOF128 computes the KMACXOF128 extendable-output keyed hash of X under
+// key K, as defined in NIST SP 800-185. L is the desired output length in
+// bits.
+func KMACXOF128(K, X []byte, L int, S string) []byte {
+	newX := kmacNewX(K, X, 168, rightEncode(0))
+	return cSHAKE128(newX, L, "KMAC", S)
+}
+
+// KMACXOF256 computes the KMACXOF256 extendable-output keyed hash of X under
+// key K, as defined in NIST SP 800-185.
+func KMACXOF256(K, X []byte, L int, S string) []byte {
+	newX := kmacNewX(K, X, 136, rightEncode(0))
+	return cSHAKE256(newX, L, "KMAC", S)
+}
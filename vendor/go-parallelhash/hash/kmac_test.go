@@ -0,0 +1,51 @@
+package hash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKMACKeyDependent(t *testing.T) {
+	X := []byte("message")
+	a := KMAC128([]byte("key-one"), X, 256, "")
+	b := KMAC128([]byte("key-two"), X, 256, "")
+	if bytes.Equal(a, b) {
+		t.Fatal("KMAC128 produced the same output under different keys")
+	}
+}
+
+func TestKMACDeterministic(t *testing.T) {
+	K := []byte("key")
+	X := []byte("message")
+	a := KMAC256(K, X, 512, "s")
+	b := KMAC256(K, X, 512, "s")
+	if !bytes.Equal(a, b) {
+		t.Fatal("KMAC256 is not deterministic")
+	}
+	if len(a) != 512/8 {
+		t.Fatalf("KMAC256 returned %d bytes, want %d", len(a), 512/8)
+	}
+}
+
+// TestKMACXOFDiffersFromKMAC checks that KMAC and KMACXOF use different
+// right_encode(L) suffixes (L vs 0), as SP 800-185 requires, so they do not
+// collide even for the same key, message, and L.
+func TestKMACXOFDiffersFromKMAC(t *testing.T) {
+	K := []byte("key")
+	X := []byte("message")
+	fixed := KMAC128(K, X, 256, "")
+	xof := KMACXOF128(K, X, 256, "")
+	if bytes.Equal(fixed, xof) {
+		t.Fatal("KMAC128 and KMACXOF128 must not agree for the same key, message, and L")
+	}
+}
+
+func TestKMACCustomizationStringChangesOutput(t *testing.T) {
+	K := []byte("key")
+	X := []byte("message")
+	a := KMAC256(K, X, 256, "alpha")
+	b := KMAC256(K, X, 256, "beta")
+	if bytes.Equal(a, b) {
+		t.Fatal("KMAC256 ignored the customization string S")
+	}
+}
@@ -0,0 +1,134 @@
+package hash
+
+import "errors"
+
+// ErrInvalidBlockSize is returned by MerkleParallelHash256 when B is not a
+// positive number of bytes.
+var ErrInvalidBlockSize = errors.New("hash: block size B must be positive")
+
+// merkleCombine deterministically combines a pair of sibling digests into
+// their parent node. It reuses the same leftEncode/rightEncode/cSHAKE256
+// building blocks as the rest of this package -- rather than a bespoke
+// combine function -- so the Merkle layer is built from the same
+// domain-separation primitives as ParallelHash256Goroutines.
+func merkleCombine(left, right []byte, S string) []byte {
+	data := append(leftEncode(len(left)), left...)
+	data = append(data, rightEncode(len(right))...)
+	data = append(data, right...)
+	return cSHAKE256(data, 512, "ParallelHash", S)
+}
+
+// MerkleParallelHash256 computes the ParallelHash256 (NIST SP 800-185) of X
+// with block size B, output length L (bits), and customization string S --
+// root is bit-for-bit identical to ParallelHash256Goroutines(X, B, L, S),
+// since it is computed from the very same per-block intermediates -- and
+// additionally returns the full binary Merkle tree built over those
+// intermediates, in level order from the leaves (index 0) up to the single
+// root node (the last element), with an odd node at any level duplicated
+// to pair with itself, as is standard for binary Merkle trees.
+//
+// The Merkle tree's own root (tree[len(tree)-1]) is a different value from
+// root: root is the flat NIST digest required for ledger compatibility,
+// while the Merkle tree exists so that a caller holding only a block and an
+// O(log n) sibling path (see VerifyMerkleProof) can prove that block was
+// part of X without rehashing the rest of it. Pass tree[len(tree)-1], not
+// root, as VerifyMerkleProof's root argument.
+func MerkleParallelHash256(X []byte, B int, L int, S string) (root []byte, tree [][]byte, err error) {
+	if B <= 0 {
+		return nil, nil, ErrInvalidBlockSize
+	}
+
+	blockCount := (len(X) + B - 1) / B
+	if blockCount == 0 {
+		blockCount = 1
+	}
+
+	leaves := make([][]byte, blockCount)
+	for i := 0; i < blockCount; i++ {
+		start := i * B
+		end := start + B
+		if end > len(X) {
+			end = len(X)
+		}
+		leaves[i] = cSHAKE256(X[start:end], 512, "", "")
+	}
+
+	var z []byte
+	z = append(z, leftEncode(B)...)
+	for _, h := range leaves {
+		z = append(z, h...)
+	}
+	z = append(z, rightEncode(blockCount)...)
+	z = append(z, rightEncode(L)...)
+	root = cSHAKE256(z, L, "ParallelHash", S)
+
+	tree = make([][]byte, 0, 2*blockCount)
+	tree = append(tree, leaves...)
+
+	level := leaves
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			left := level[i]
+			right := left
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, merkleCombine(left, right, S))
+		}
+		tree = append(tree, next...)
+		level = next
+	}
+
+	return root, tree, nil
+}
+
+// VerifyMerkleProof checks that leaf is the block at index out of
+// blockCount total blocks committed to by root, given the O(log n) sibling
+// path proof returned alongside a MerkleParallelHash256 tree. root must be
+// the Merkle tree's own root (tree[len(tree)-1] from MerkleParallelHash256),
+// and leaf must be the same per-block intermediate stored in
+// MerkleParallelHash256's tree (i.e. tree[index]), not the raw block bytes.
+//
+// This signature has no S parameter, so it only verifies proofs built with
+// an empty customization string; call VerifyMerkleProofWithS for a
+// non-empty S.
+func VerifyMerkleProof(leaf, root []byte, proof [][]byte, index, blockCount int) bool {
+	return VerifyMerkleProofWithS(leaf, root, proof, index, blockCount, "")
+}
+
+// VerifyMerkleProofWithS is VerifyMerkleProof for a tree built with a
+// non-empty customization string: S must match the S passed to the
+// MerkleParallelHash256 call that produced root, or no proof will verify.
+func VerifyMerkleProofWithS(leaf, root []byte, proof [][]byte, index, blockCount int, S string) bool {
+	if blockCount <= 0 || index < 0 || index >= blockCount {
+		return false
+	}
+
+	current := leaf
+	levelSize := blockCount
+	for _, sibling := range proof {
+		isRight := index%2 == 1
+		if isRight {
+			current = merkleCombine(sibling, current, S)
+		} else if index+1 < levelSize {
+			current = merkleCombine(current, sibling, S)
+		} else {
+			// index is the last, unpaired node at this level: it is
+			// duplicated against itself, matching MerkleParallelHash256.
+			current = merkleCombine(current, current, S)
+		}
+		index /= 2
+		levelSize = (levelSize + 1) / 2
+	}
+
+	if len(current) != len(root) {
+		return false
+	}
+	for i := range current {
+		if current[i] != root[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package hash
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// buildProof walks the level-order tree returned by MerkleParallelHash256
+// to construct the O(log n) sibling path for leaf index, mirroring what a
+// real caller would do to produce an inclusion proof.
+func buildProof(tree [][]byte, blockCount, index int) [][]byte {
+	var proof [][]byte
+	levelStart := 0
+	levelSize := blockCount
+	for levelSize > 1 {
+		sibIndex := index ^ 1
+		if sibIndex >= levelSize {
+			sibIndex = index
+		}
+		proof = append(proof, tree[levelStart+sibIndex])
+		index /= 2
+		levelStart += levelSize
+		levelSize = (levelSize + 1) / 2
+	}
+	return proof
+}
+
+func TestMerkleParallelHash256RootMatchesFlat(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.Read(data)
+	B := 1000
+
+	root, tree, err := MerkleParallelHash256(data, B, 256, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ParallelHash256Goroutines(data, B, 256, "s")
+	if !bytes.Equal(root, expected) {
+		t.Fatalf("root mismatch:\n got=%x\nwant=%x", root, expected)
+	}
+
+	blockCount := (len(data) + B - 1) / B
+	merkleRoot := tree[len(tree)-1]
+	index := 2
+	proof := buildProof(tree, blockCount, index)
+
+	// VerifyMerkleProof (S="") must reject a tree built with a non-empty S.
+	if VerifyMerkleProof(tree[index], merkleRoot, proof, index, blockCount) {
+		t.Fatalf("VerifyMerkleProof (S=\"\") should not verify a proof built with S=\"s\"")
+	}
+
+	// VerifyMerkleProofWithS, given the matching S, must accept it.
+	if !VerifyMerkleProofWithS(tree[index], merkleRoot, proof, index, blockCount, "s") {
+		t.Fatalf("VerifyMerkleProofWithS did not verify a valid proof")
+	}
+
+	tampered := append([]byte(nil), tree[index]...)
+	tampered[0] ^= 0xFF
+	if VerifyMerkleProofWithS(tampered, merkleRoot, proof, index, blockCount, "s") {
+		t.Fatalf("VerifyMerkleProofWithS verified a tampered leaf")
+	}
+}
+
+func TestVerifyMerkleProofEmptyS(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.Read(data)
+	B := 1000
+
+	_, tree, err := MerkleParallelHash256(data, B, 256, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockCount := (len(data) + B - 1) / B
+	merkleRoot := tree[len(tree)-1]
+	index := 4
+	proof := buildProof(tree, blockCount, index)
+
+	if !VerifyMerkleProof(tree[index], merkleRoot, proof, index, blockCount) {
+		t.Fatalf("VerifyMerkleProof did not verify a valid proof built with S=\"\"")
+	}
+}
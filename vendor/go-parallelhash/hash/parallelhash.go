@@ -4,6 +4,7 @@ package hash
 
 import (
 	"golang.org/x/crypto/sha3"
+	"runtime"
 	"sync"
 )
 
@@ -120,10 +121,11 @@ func cSHAKE256(X []byte, L int, N, S string) []byte {
 // function using one goroutine per data block for parallel processing.
 //
 // Parameters:
-//   X: The input message.
-//   B: The block size in bytes for parallel processing.
-//   L: The desired output length in bits.
-//   S: A customization string.
+//
+//	X: The input message.
+//	B: The block size in bytes for parallel processing.
+//	L: The desired output length in bits.
+//	S: A customization string.
 func ParallelHash128Goroutines(X []byte, B int, L int, S string) []byte {
 	// The underlying hash function for intermediate blocks is a plain SHAKE128,
 	// which is equivalent to cSHAKE128 with empty N and S strings.
@@ -202,3 +204,70 @@ func parallelHashGoroutines(X []byte, B int, L int, intermediateHash, finalHash
 	// Compute and return the final hash.
 	return finalHash(z)
 }
+
+// intermediatePool recycles the byte slices used to hold per-block
+// intermediate hashes in parallelHashWorkerPool across calls. Each
+// intermediateHash call still allocates its own digest, which is copied
+// into the pooled slice.
+var intermediatePool = sync.Pool{
+	New: func() interface{} { return make([]byte, 0, 64) },
+}
+
+// parallelHashWorkerPool is the bounded-worker-pool counterpart of
+// parallelHashGoroutines: instead of spawning one goroutine per block, it
+// feeds block indices to a fixed pool of runtime.NumCPU() workers over a
+// channel, drawing each block's intermediate slice from intermediatePool.
+func parallelHashWorkerPool(X []byte, B int, L int, intermediateHash, finalHash func([]byte) []byte) []byte {
+	blockCount := (len(X) + B - 1) / B
+	if blockCount == 0 {
+		blockCount = 1
+	}
+
+	intermediates := make([][]byte, blockCount)
+
+	numWorkers := runtime.NumCPU()
+	if numWorkers == 0 {
+		numWorkers = 1
+	}
+	if numWorkers > blockCount {
+		numWorkers = blockCount
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer wg.Done()
+			for blockIndex := range jobs {
+				start := blockIndex * B
+				end := start + B
+				if end > len(X) {
+					end = len(X)
+				}
+				block := X[start:end]
+
+				scratch := intermediatePool.Get().([]byte)[:0]
+				scratch = append(scratch, intermediateHash(block)...)
+				intermediates[blockIndex] = scratch
+			}
+		}()
+	}
+	for i := 0; i < blockCount; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var z []byte
+	z = append(z, leftEncode(B)...)
+	for _, h := range intermediates {
+		z = append(z, h...)
+		intermediatePool.Put(h) //nolint:staticcheck // scratch reused, not retained past this loop
+	}
+
+	z = append(z, rightEncode(blockCount)...)
+	z = append(z, rightEncode(L)...)
+
+	return finalHash(z)
+}
@@ -0,0 +1,116 @@
+package hash
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+)
+
+// parallelHashAdapterPool recycles ParallelHashAdapter instances so that
+// callers on hot paths (e.g. hashing every block/snapshot written to a
+// ledger) can obtain one via NewParallelHash256 without an allocation per
+// call.
+var parallelHashAdapterPool = sync.Pool{
+	New: func() interface{} { return new(ParallelHashAdapter) },
+}
+
+// ParallelHashAdapter implements the standard hash.Hash interface to wrap
+// the non-streaming ParallelHash256 function. Construct it with
+// NewParallelHash256 rather than a literal, so it is drawn from (and can be
+// returned to) parallelHashAdapterPool.
+type ParallelHashAdapter struct {
+	buffer bytes.Buffer
+	L      int    // Output length in bits
+	S      string // Customization string
+	B      int    // Block size in bytes; 0 means auto-tune from input size
+}
+
+// NewParallelHash256 returns a ParallelHashAdapter for output length L
+// (bits) and customization string S, drawn from a sync.Pool. The block size
+// defaults to auto-tuning from the input size at Sum time, matching prior
+// behavior; set the B field directly before calling Sum to use a fixed
+// block size instead. Callers done with the adapter should call Release to
+// return it to the pool.
+func NewParallelHash256(L int, S string) *ParallelHashAdapter {
+	a := parallelHashAdapterPool.Get().(*ParallelHashAdapter)
+	a.buffer.Reset()
+	a.L = L
+	a.S = S
+	a.B = 0
+	return a
+}
+
+// Release returns a to the pool for reuse by a future NewParallelHash256
+// call. It is optional: a ParallelHashAdapter not obtained from
+// NewParallelHash256, or never released, still works correctly.
+func (a *ParallelHashAdapter) Release() {
+	parallelHashAdapterPool.Put(a)
+}
+
+// Write appends data to an internal buffer. It is part of the hash.Hash interface.
+func (a *ParallelHashAdapter) Write(p []byte) (n int, err error) {
+	return a.buffer.Write(p)
+}
+
+// Sum calculates the ParallelHash256 of the entire buffer and returns the
+// result. Block hashing runs across a worker pool bounded by
+// runtime.NumCPU(), holding the intermediates in pooled slices, rather than
+// spawning one goroutine per block.
+func (a *ParallelHashAdapter) Sum(b []byte) []byte {
+	totalSize := a.buffer.Len()
+
+	B := a.B
+	if B == 0 {
+		B = autoTuneBlockSize(totalSize)
+	}
+
+	result := parallelHashWorkerPool(a.buffer.Bytes(), B, a.L, shake256Intermediate, parallelHash256Final(a.S, a.L))
+	return append(b, result...)
+}
+
+// autoTuneBlockSize picks a block size that divides totalSize evenly across
+// the available CPUs, never going below minBlockSize; an empty input gets
+// B=1. This is the same heuristic ParallelHashAdapter.Sum always used; it is
+// now only applied when the caller leaves B at its zero value.
+func autoTuneBlockSize(totalSize int) int {
+	if totalSize == 0 {
+		return 1
+	}
+	numCPU := runtime.NumCPU()
+	if numCPU == 0 {
+		numCPU = 1
+	}
+
+	optimalBlockSize := totalSize / numCPU
+
+	const minBlockSize = 1024 * 64 // 64KB
+	if optimalBlockSize < minBlockSize {
+		optimalBlockSize = minBlockSize
+	}
+	return optimalBlockSize
+}
+
+func shake256Intermediate(data []byte) []byte {
+	return cSHAKE256(data, 512, "", "")
+}
+
+func parallelHash256Final(S string, L int) func([]byte) []byte {
+	return func(data []byte) []byte {
+		return cSHAKE256(data, L, "ParallelHash", S)
+	}
+}
+
+// Reset clears the buffer for the next use. It is part of the hash.Hash interface.
+func (a *ParallelHashAdapter) Reset() {
+	a.buffer.Reset()
+}
+
+// Size returns the output size in bytes. It is part of the hash.Hash interface.
+func (a *ParallelHashAdapter) Size() int {
+	return a.L / 8
+}
+
+// BlockSize can return 1 for a streaming hash. It is part of the hash.Hash interface.
+func (a *ParallelHashAdapter) BlockSize() int {
+	return 1
+}
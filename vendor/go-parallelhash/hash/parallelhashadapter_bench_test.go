@@ -0,0 +1,83 @@
+package hash
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// benchSizes covers the 1 MB / 100 MB / 1 GB inputs called out when the
+// worker-pool ParallelHashAdapter replaced the one-goroutine-per-block
+// original.
+var benchSizes = []int{
+	1 << 20,   // 1 MB
+	100 << 20, // 100 MB
+	1 << 30,   // 1 GB
+}
+
+func benchData(b *testing.B, size int) []byte {
+	b.Helper()
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+// BenchmarkParallelHashAdapter_Sum measures the pooled, bounded-worker-pool
+// ParallelHashAdapter.Sum.
+func BenchmarkParallelHashAdapter_Sum(b *testing.B) {
+	for _, size := range benchSizes {
+		size := size
+		b.Run(sizeLabel(size), func(b *testing.B) {
+			data := benchData(b, size)
+			b.ResetTimer()
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				a := NewParallelHash256(256, "bench")
+				a.Write(data)
+				a.Sum(nil)
+				a.Release()
+			}
+		})
+	}
+}
+
+// BenchmarkParallelHash256Goroutines measures the original
+// one-goroutine-per-block implementation for comparison.
+func BenchmarkParallelHash256Goroutines(b *testing.B) {
+	for _, size := range benchSizes {
+		size := size
+		b.Run(sizeLabel(size), func(b *testing.B) {
+			data := benchData(b, size)
+			const minBlockSize = 1024 * 64
+			b.ResetTimer()
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				ParallelHash256Goroutines(data, minBlockSize, 256, "bench")
+			}
+		})
+	}
+}
+
+func sizeLabel(size int) string {
+	switch {
+	case size >= 1<<30:
+		return "1GB"
+	case size >= 1<<20:
+		return megabyteLabel(size)
+	default:
+		return "small"
+	}
+}
+
+func megabyteLabel(size int) string {
+	mb := size / (1 << 20)
+	switch mb {
+	case 1:
+		return "1MB"
+	case 100:
+		return "100MB"
+	default:
+		return "MB"
+	}
+}
@@ -0,0 +1,48 @@
+package hash
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// TestParallelHashAdapterMatchesGoroutines checks that
+// NewParallelHash256(...).Write(...).Sum(nil) is bit-identical to
+// ParallelHash256Goroutines for a matching B, including the empty-buffer
+// case with an explicit, non-default B -- the case where ParallelHashAdapter
+// previously ignored a.B and silently used leftEncode(1) instead.
+func TestParallelHashAdapterMatchesGoroutines(t *testing.T) {
+	data := make([]byte, 5000)
+	rand.Read(data)
+
+	cases := []struct {
+		name string
+		data []byte
+		B    int
+	}{
+		{"nonEmptyAutoTunedB", data, 0},
+		{"nonEmptyExplicitB", data, 1000},
+		{"emptyAutoTunedB", nil, 0},
+		{"emptyExplicitB", nil, 65536},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := NewParallelHash256(256, "s")
+			a.B = c.B
+			a.Write(c.data)
+			got := a.Sum(nil)
+			a.Release()
+
+			B := c.B
+			if B == 0 {
+				B = autoTuneBlockSize(len(c.data))
+			}
+			want := ParallelHash256Goroutines(c.data, B, 256, "s")
+
+			if !bytes.Equal(got, want) {
+				t.Fatalf("ParallelHashAdapter diverges from ParallelHash256Goroutines:\n got=%x\nwant=%x", got, want)
+			}
+		})
+	}
+}
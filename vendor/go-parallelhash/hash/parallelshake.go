@@ -0,0 +1,229 @@
+package hash
+
+import (
+	"golang.org/x/crypto/sha3"
+	"hash"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ParallelShakeHash is implemented by the streaming ParallelHash XOF
+// constructions returned by NewParallelHash128. It satisfies hash.Hash for
+// callers that want a fixed-length digest -- the natural minimum-security
+// output of 256 bits -- as well as io.Reader for callers that want
+// arbitrary-length XOF output, mirroring the ShakeHash interface in
+// golang.org/x/crypto/sha3.
+type ParallelShakeHash interface {
+	hash.Hash
+	io.Reader
+}
+
+// cshakeSponge is a streaming cSHAKE sponge. Write absorbs message bytes
+// directly into the underlying SHAKE state; the cSHAKE domain-separation
+// byte (0x04) is appended lazily, the moment the sponge is first squeezed,
+// so the same state can be used as either a fixed-length hash.Hash or an
+// arbitrary-length io.Reader.
+type cshakeSponge struct {
+	shake   sha3.ShakeHash
+	squeeze bool
+}
+
+func newCShakeSponge(shake sha3.ShakeHash, rate int, N, S string) *cshakeSponge {
+	c := &cshakeSponge{shake: shake}
+	if N != "" || S != "" {
+		prefix := bytepad(append(encodeString([]byte(N)), encodeString([]byte(S))...), rate)
+		c.shake.Write(prefix)
+	}
+	return c
+}
+
+func (c *cshakeSponge) clone() *cshakeSponge {
+	return &cshakeSponge{shake: c.shake.Clone(), squeeze: c.squeeze}
+}
+
+func (c *cshakeSponge) Write(p []byte) (int, error) {
+	if c.squeeze {
+		panic("hash: Write after the ParallelShakeHash output has begun")
+	}
+	return c.shake.Write(p)
+}
+
+func (c *cshakeSponge) Read(p []byte) (int, error) {
+	if !c.squeeze {
+		c.squeeze = true
+		c.shake.Write([]byte{0x04})
+	}
+	return c.shake.Read(p)
+}
+
+// parallelShakeState is the streaming implementation backing
+// NewParallelHash128. Unlike ParallelHash128Goroutines, it never holds the
+// whole message in memory: each Write call hashes the B-byte blocks it just
+// completed across a worker pool bounded by runtime.NumCPU(), absorbs the
+// resulting intermediates into the outer cSHAKE sponge in original block
+// order, and discards everything except the sponge state and the current
+// (sub-B-byte) partial block.
+type parallelShakeState struct {
+	B          int
+	S          string
+	outerRate  int // cSHAKE128 rate, in bytes
+	digestBits int // natural minimum-security fixed output length
+
+	newShake  func() sha3.ShakeHash
+	blockHash func([]byte) []byte // plain SHAKE over a single block
+
+	buf         []byte
+	blockCount  int
+	outer       *cshakeSponge
+	leadWritten bool
+
+	// finalized caches the result of finalize, so that repeated Read calls
+	// keep squeezing further bytes from the same sponge instead of each
+	// starting the XOF stream over from byte zero.
+	finalized *cshakeSponge
+}
+
+func newParallelShakeState(B int, S string, outerRate, digestBits int, newShake func() sha3.ShakeHash, blockHash func([]byte) []byte) *parallelShakeState {
+	return &parallelShakeState{
+		B:          B,
+		S:          S,
+		outerRate:  outerRate,
+		digestBits: digestBits,
+		newShake:   newShake,
+		blockHash:  blockHash,
+		outer:      newCShakeSponge(newShake(), outerRate, "ParallelHash", S),
+	}
+}
+
+// absorbBlocks hashes blocks across a worker pool bounded by runtime.NumCPU()
+// and absorbs the resulting intermediates into the outer sponge in original
+// order.
+func (p *parallelShakeState) absorbBlocks(blocks [][]byte) {
+	if len(blocks) == 0 {
+		return
+	}
+	digests := make([][]byte, len(blocks))
+
+	numWorkers := runtime.NumCPU()
+	if numWorkers > len(blocks) {
+		numWorkers = len(blocks)
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				digests[i] = p.blockHash(blocks[i])
+			}
+		}()
+	}
+	for i := range blocks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if !p.leadWritten {
+		p.outer.Write(leftEncode(p.B))
+		p.leadWritten = true
+	}
+	for _, d := range digests {
+		p.outer.Write(d)
+	}
+	p.blockCount += len(blocks)
+}
+
+// Write implements io.Writer, absorbing complete B-byte blocks as soon as
+// they are available and retaining only the trailing partial block.
+func (p *parallelShakeState) Write(data []byte) (int, error) {
+	if p.finalized != nil {
+		panic("hash: Write after the ParallelShakeHash output has begun")
+	}
+	n := len(data)
+	p.buf = append(p.buf, data...)
+
+	var blocks [][]byte
+	for len(p.buf) >= p.B {
+		blocks = append(blocks, p.buf[:p.B:p.B])
+		p.buf = p.buf[p.B:]
+	}
+	p.absorbBlocks(blocks)
+	if len(blocks) > 0 {
+		// Copy the partial tail out so the absorbed blocks' backing array
+		// is not kept alive.
+		p.buf = append([]byte(nil), p.buf...)
+	}
+	return n, nil
+}
+
+// finalize returns a clone of p with the trailing partial block absorbed and
+// the block-count/output-length suffix written, ready to be squeezed.
+func (p *parallelShakeState) finalize(L int) *parallelShakeState {
+	clone := &parallelShakeState{
+		B: p.B, S: p.S, outerRate: p.outerRate, digestBits: p.digestBits,
+		newShake: p.newShake, blockHash: p.blockHash,
+		buf: append([]byte(nil), p.buf...), blockCount: p.blockCount,
+		outer: p.outer.clone(), leadWritten: p.leadWritten,
+	}
+	if len(clone.buf) > 0 || clone.blockCount == 0 {
+		clone.absorbBlocks([][]byte{clone.buf})
+		clone.buf = nil
+	}
+	clone.outer.Write(rightEncode(clone.blockCount))
+	clone.outer.Write(rightEncode(L))
+	return clone
+}
+
+// ensureFinalized finalizes p at most once, caching the resulting sponge so
+// that successive Read calls squeeze further bytes from it rather than
+// restarting the XOF stream from byte zero each time.
+func (p *parallelShakeState) ensureFinalized() *cshakeSponge {
+	if p.finalized == nil {
+		p.finalized = p.finalize(p.digestBits).outer
+	}
+	return p.finalized
+}
+
+// Read squeezes the next bytes of XOF output, continuing from wherever the
+// previous Read call left off.
+func (p *parallelShakeState) Read(out []byte) (int, error) {
+	return p.ensureFinalized().Read(out)
+}
+
+// Sum returns the fixed natural-security digest. Per the hash.Hash contract
+// it does not change the underlying state: it squeezes a separately
+// finalized clone of p, so it neither consumes bytes a later Read would
+// otherwise squeeze, nor changes on repeated calls, nor blocks further
+// Writes.
+func (p *parallelShakeState) Sum(b []byte) []byte {
+	out := make([]byte, p.digestBits/8)
+	p.finalize(p.digestBits).outer.Read(out)
+	return append(b, out...)
+}
+
+func (p *parallelShakeState) Reset() {
+	p.buf = nil
+	p.blockCount = 0
+	p.leadWritten = false
+	p.finalized = nil
+	p.outer = newCShakeSponge(p.newShake(), p.outerRate, "ParallelHash", p.S)
+}
+
+func (p *parallelShakeState) Size() int      { return p.digestBits / 8 }
+func (p *parallelShakeState) BlockSize() int { return p.B }
+
+// NewParallelHash128 returns a streaming ParallelHash128 (NIST SP 800-185)
+// state. Message bytes are absorbed incrementally via Write: each completed
+// B-byte block is hashed across a worker pool bounded by runtime.NumCPU()
+// and only the resulting intermediate digests are retained, so the full
+// message is never held in memory. The result can be used as a fixed
+// 256-bit hash.Hash via Sum, or read as arbitrary-length XOF output via
+// Read, mirroring sha3.ShakeHash.
+func NewParallelHash128(B int, S string) ParallelShakeHash {
+	return newParallelShakeState(B, S, 168, 256, sha3.NewShake128, func(data []byte) []byte {
+		return cSHAKE128(data, 256, "", "")
+	})
+}
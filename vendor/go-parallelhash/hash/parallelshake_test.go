@@ -0,0 +1,67 @@
+package hash
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// TestParallelShakeReadIsProgressive checks that repeated Read calls
+// continue squeezing the XOF stream instead of each restarting it from byte
+// zero -- two successive 32-byte Reads must equal one 64-byte Read.
+func TestParallelShakeReadIsProgressive(t *testing.T) {
+	data := make([]byte, 5000)
+	rand.Read(data)
+
+	oneShot := NewParallelHash128(200, "s")
+	oneShot.Write(data)
+	big := make([]byte, 64)
+	if _, err := oneShot.Read(big); err != nil {
+		t.Fatal(err)
+	}
+
+	split := NewParallelHash128(200, "s")
+	split.Write(data)
+	first := make([]byte, 32)
+	second := make([]byte, 32)
+	if _, err := split.Read(first); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := split.Read(second); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(big[:32], first) {
+		t.Fatalf("first chunk mismatch:\n got=%x\nwant=%x", first, big[:32])
+	}
+	if !bytes.Equal(big[32:], second) {
+		t.Fatalf("second chunk mismatch:\n got=%x\nwant=%x", second, big[32:])
+	}
+	if bytes.Equal(first, second) {
+		t.Fatalf("second Read returned the same bytes as the first")
+	}
+}
+
+// TestParallelShakeSumIsIdempotent checks that Sum does not advance the XOF
+// stream or change on repeated calls, matching the hash.Hash contract.
+func TestParallelShakeSumIsIdempotent(t *testing.T) {
+	data := make([]byte, 5000)
+	rand.Read(data)
+
+	h := NewParallelHash128(200, "s")
+	h.Write(data)
+
+	sum1 := h.Sum(nil)
+	sum2 := h.Sum(nil)
+	if !bytes.Equal(sum1, sum2) {
+		t.Fatalf("Sum not idempotent:\n%x\n%x", sum1, sum2)
+	}
+
+	out := make([]byte, 32)
+	if _, err := h.Read(out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, sum1) {
+		t.Fatalf("Read after Sum should still start from byte zero:\n got=%x\nwant=%x", out, sum1)
+	}
+}
@@ -5,6 +5,7 @@ package hash
 import (
 	"crypto/sha256"
 	"golang.org/x/crypto/sha3"
+	"hash"
 )
 
 // SHA256Hash computes the SHA-256 hash of the input data.
@@ -45,3 +46,29 @@ func SHAKE256Hash(data []byte, outLenBits int) []byte {
 	sha3.ShakeSum256(out, data)
 	return out
 }
+
+// Keccak256Hash computes the original (pre-standardization) Keccak-256 hash
+// of data. Unlike SHA3256Hash, this uses Keccak's original domain-separation
+// byte (0x01) rather than the 0x06 NIST settled on for SHA-3, matching what
+// Ethereum and other blockchain protocols expect for address checksums,
+// transaction hashing, and Merkle-Patricia trie key derivation.
+func Keccak256Hash(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Keccak512Hash computes the original (pre-standardization) Keccak-512 hash
+// of data, the 512-bit counterpart to Keccak256Hash.
+func Keccak512Hash(data []byte) []byte {
+	h := sha3.NewLegacyKeccak512()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// NewLegacyKeccak256 returns a streaming hash.Hash computing the original
+// Keccak-256, for callers that want to write data incrementally rather than
+// hash it in one call via Keccak256Hash.
+func NewLegacyKeccak256() hash.Hash {
+	return sha3.NewLegacyKeccak256()
+}
@@ -0,0 +1,49 @@
+package hash
+
+// --- TupleHash Implementations (NIST SP 800-185) ---
+
+// tupleHashEncode builds newX = encode_string(X_1) || ... || encode_string(X_n)
+// for the given tuple of byte strings.
+func tupleHashEncode(X [][]byte) []byte {
+	var newX []byte
+	for _, Xi := range X {
+		newX = append(newX, encodeString(Xi)...)
+	}
+	return newX
+}
+
+// TupleHash128 computes the TupleHash128 of the tuple X, as defined in NIST
+// SP 800-185. Unlike hashing the concatenation of X, TupleHash is unambiguous
+// about where each string in the tuple starts and ends.
+//
+// Parameters:
+//
+//	X: The tuple of input byte strings.
+//	L: The desired output length in bits.
+//	S: A customization string.
+func TupleHash128(X [][]byte, L int, S string) []byte {
+	newX := append(tupleHashEncode(X), rightEncode(L)...)
+	return cSHAKE128(newX, L, "TupleHash", S)
+}
+
+// TupleHash256 computes the TupleHash256 of the tuple X, as defined in NIST
+// SP 800-185.
+func TupleHash256(X [][]byte, L int, S string) []byte {
+	newX := append(tupleHashEncode(X), rightEncode(L)...)
+	return cSHAKE256(newX, L, "TupleHash", S)
+}
+
+// TupleHashXOF128 computes the TupleHashXOF128 extendable-output function
+// over the tuple X, as defined in NIST SP 800-185. L is the desired output
+// length in bits.
+func TupleHashXOF128(X [][]byte, L int, S string) []byte {
+	newX := append(tupleHashEncode(X), rightEncode(0)...)
+	return cSHAKE128(newX, L, "TupleHash", S)
+}
+
+// TupleHashXOF256 computes the TupleHashXOF256 extendable-output function
+// over the tuple X, as defined in NIST SP 800-185.
+func TupleHashXOF256(X [][]byte, L int, S string) []byte {
+	newX := append(tupleHashEncode(X), rightEncode(0)...)
+	return cSHAKE256(newX, L, "TupleHash", S)
+}
@@ -0,0 +1,52 @@
+package hash
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestTupleHashUnambiguousEncoding is TupleHash's whole reason for existing
+// over hashing a plain concatenation: encode_string-prefixing each tuple
+// element must make the boundary between elements part of the hash input,
+// so ("ab", "c") and ("a", "bc") -- which concatenate to the same bytes --
+// must hash to different values.
+func TestTupleHashUnambiguousEncoding(t *testing.T) {
+	a := TupleHash256([][]byte{[]byte("ab"), []byte("c")}, 256, "")
+	b := TupleHash256([][]byte{[]byte("a"), []byte("bc")}, 256, "")
+	if bytes.Equal(a, b) {
+		t.Fatal("TupleHash256 did not distinguish different tuple boundaries over the same concatenation")
+	}
+}
+
+func TestTupleHashDeterministic(t *testing.T) {
+	X := [][]byte{[]byte("one"), []byte("two"), []byte("three")}
+	a := TupleHash128(X, 256, "s")
+	b := TupleHash128(X, 256, "s")
+	if !bytes.Equal(a, b) {
+		t.Fatal("TupleHash128 is not deterministic")
+	}
+	if len(a) != 256/8 {
+		t.Fatalf("TupleHash128 returned %d bytes, want %d", len(a), 256/8)
+	}
+}
+
+// TestTupleHashXOFDiffersFromFixedLength checks that TupleHash and
+// TupleHashXOF use different right_encode(L) suffixes (L vs 0), as SP
+// 800-185 requires, so they do not collide even for the same tuple and L.
+func TestTupleHashXOFDiffersFromFixedLength(t *testing.T) {
+	X := [][]byte{[]byte("payload")}
+	fixed := TupleHash128(X, 256, "")
+	xof := TupleHashXOF128(X, 256, "")
+	if bytes.Equal(fixed, xof) {
+		t.Fatal("TupleHash128 and TupleHashXOF128 must not agree for the same tuple and L")
+	}
+}
+
+func TestTupleHashCustomizationStringChangesOutput(t *testing.T) {
+	X := [][]byte{[]byte("payload")}
+	a := TupleHash256(X, 256, "alpha")
+	b := TupleHash256(X, 256, "beta")
+	if bytes.Equal(a, b) {
+		t.Fatal("TupleHash256 ignored the customization string S")
+	}
+}